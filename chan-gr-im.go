@@ -1,9 +1,5 @@
 package slack
 
-import (
-	"net/url"
-)
-
 // ChannelTopicPurpose holds the topic or purpose of a channel
 type ChannelTopicPurpose struct {
 	Value   string `json:"value"`
@@ -76,102 +72,121 @@ type GroupListResponse struct {
 }
 
 // ChannelArchive archives a channel
+//
+// Deprecated: use ConversationsArchive instead.
 func (s *Slack) ChannelArchive(channel string) (Response, error) {
-	params := url.Values{"channel": {channel}}
-	r := &slackResponse{}
-	err := s.do("channels.archive", params, r)
-	if err != nil {
-		return nil, err
-	}
-	return r, nil
+	return s.ConversationsArchive(channel)
 }
 
 // ChannelCreate creates a channel
+//
+// Deprecated: use ConversationsCreate instead.
 func (s *Slack) ChannelCreate(name string) (*ChannelResponse, error) {
-	params := url.Values{"name": {name}}
-	r := &ChannelResponse{}
-	err := s.do("channels.create", params, r)
+	resp, err := s.ConversationsCreate(name, false)
 	if err != nil {
 		return nil, err
 	}
-	return r, nil
+	return &ChannelResponse{slackResponse: resp.slackResponse, Channel: conversationToChannel(resp.Channel)}, nil
 }
 
 // ChannelInfo returns info about the channel
+//
+// Deprecated: use ConversationsInfo instead.
 func (s *Slack) ChannelInfo(channel string) (*ChannelResponse, error) {
-	params := url.Values{"channel": {channel}}
-	r := &ChannelResponse{}
-	err := s.do("channels.info", params, r)
+	resp, err := s.ConversationsInfo(channel)
 	if err != nil {
 		return nil, err
 	}
-	return r, nil
+	return &ChannelResponse{slackResponse: resp.slackResponse, Channel: conversationToChannel(resp.Channel)}, nil
 }
 
 // ChannelList returns the list of channels
+//
+// Deprecated: use ConversationsList instead.
 func (s *Slack) ChannelList(excludeArchived bool) (*ChannelListResponse, error) {
-	params := url.Values{}
-	if excludeArchived {
-		params.Set("exclude_archived", "1")
-	}
-	r := &ChannelListResponse{}
-	err := s.do("channels.list", params, r)
+	all, last, err := s.conversationsListAll([]string{"public_channel"})
 	if err != nil {
 		return nil, err
 	}
-	return r, nil
+	channels := make([]Channel, 0, len(all))
+	for _, c := range all {
+		if excludeArchived && c.IsArchived {
+			continue
+		}
+		channels = append(channels, conversationToChannel(c))
+	}
+	return &ChannelListResponse{slackResponse: last.slackResponse, Channels: channels}, nil
 }
 
 // Mark marks the given channel as read. Automatically detects channel/group/im
+//
+// Deprecated: use ConversationsMark instead, which Slack handles uniformly
+// without a channel[0:1] prefix dispatch.
 func (s *Slack) Mark(channel, ts string) error {
-	r := &slackResponse{}
-	params := url.Values{"channel": {channel}, "ts": {ts}}
-	path := "channels.mark"
-	switch channel[0:1] {
-	case "G":
-		path = "groups.mark"
-	case "D":
-		path = "im.mark"
-	}
-	err := s.do(path, params, r)
-	if err != nil {
-		return err
-	}
-	return nil
+	return s.ConversationsMark(channel, ts)
 }
 
 // GroupCreate creates a new group with the given name
+//
+// Deprecated: use ConversationsCreate with isPrivate=true instead.
 func (s *Slack) GroupCreate(name string) (*GroupResponse, error) {
-	params := url.Values{"name": {name}}
-	r := &GroupResponse{}
-	err := s.do("groups.create", params, r)
+	resp, err := s.ConversationsCreate(name, true)
 	if err != nil {
 		return nil, err
 	}
-	return r, nil
+	return &GroupResponse{slackResponse: resp.slackResponse, Group: conversationToGroup(resp.Channel)}, nil
 }
 
 // GroupInvite invites a user to a group
+//
+// Deprecated: use ConversationsInvite instead.
 func (s *Slack) GroupInvite(channel, user string) (*GroupResponse, error) {
-	params := url.Values{"channel": {channel}, "user": {user}}
-	r := &GroupResponse{}
-	err := s.do("groups.invite", params, r)
+	resp, err := s.ConversationsInvite(channel, []string{user})
 	if err != nil {
 		return nil, err
 	}
-	return r, nil
+	return &GroupResponse{slackResponse: resp.slackResponse, Group: conversationToGroup(resp.Channel)}, nil
 }
 
 // GroupList returns the list of channels
+//
+// Deprecated: use ConversationsList instead. Note this only ever returns
+// private channels, matching the scope of the legacy groups.list endpoint;
+// pass "mpim" to ConversationsList directly if you also want MPIMs.
 func (s *Slack) GroupList(excludeArchived bool) (*GroupListResponse, error) {
-	params := url.Values{}
-	if excludeArchived {
-		params.Set("exclude_archived", "1")
-	}
-	r := &GroupListResponse{}
-	err := s.do("groups.list", params, r)
+	all, last, err := s.conversationsListAll([]string{"private_channel"})
 	if err != nil {
 		return nil, err
 	}
-	return r, nil
-}
\ No newline at end of file
+	groups := make([]Group, 0, len(all))
+	for _, c := range all {
+		if excludeArchived && c.IsArchived {
+			continue
+		}
+		groups = append(groups, conversationToGroup(c))
+	}
+	return &GroupListResponse{slackResponse: last.slackResponse, Groups: groups}, nil
+}
+
+// conversationsListAll pages through ConversationsList for the given types
+// until NextCursor is exhausted, so the deprecated ChannelList/GroupList
+// wrappers keep returning every matching conversation the way the legacy
+// non-paginated channels.list/groups.list endpoints did.
+func (s *Slack) conversationsListAll(types []string) ([]Conversation, *ConversationListResponse, error) {
+	var all []Conversation
+	var last *ConversationListResponse
+	cursor := ""
+	for {
+		resp, err := s.ConversationsList(types, cursor, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+		all = append(all, resp.Channels...)
+		last = resp
+		cursor = resp.ResponseMetadata.NextCursor
+		if cursor == "" {
+			break
+		}
+	}
+	return all, last, nil
+}
@@ -0,0 +1,201 @@
+package slack
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Conversation holds information about a conversation: a public channel,
+// private channel, MPIM or DM. It supersedes Channel, Group and IM, which
+// are kept only as thin deprecated wrappers around the conversations.* API.
+type Conversation struct {
+	BaseChannel
+	IsChannel bool   `json:"is_channel"`
+	IsGroup   bool   `json:"is_group"`
+	IsIM      bool   `json:"is_im"`
+	IsMPIM    bool   `json:"is_mpim"`
+	IsPrivate bool   `json:"is_private"`
+	User      string `json:"user,omitempty"`
+}
+
+// responseMetadata carries the cursor for the next page of a cursor-paginated
+// conversations.* response
+type responseMetadata struct {
+	NextCursor string `json:"next_cursor"`
+}
+
+// ConversationResponse holds a response to a single-conversation request
+type ConversationResponse struct {
+	slackResponse
+	Channel Conversation `json:"channel"`
+}
+
+// ConversationListResponse holds a response to conversations.list
+type ConversationListResponse struct {
+	slackResponse
+	Channels         []Conversation   `json:"channels"`
+	ResponseMetadata responseMetadata `json:"response_metadata,omitempty"`
+}
+
+// ConversationsHistoryResponse holds a response to conversations.history
+type ConversationsHistoryResponse struct {
+	slackResponse
+	Messages         []Message        `json:"messages"`
+	HasMore          bool             `json:"has_more"`
+	ResponseMetadata responseMetadata `json:"response_metadata,omitempty"`
+}
+
+// ConversationsMembersResponse holds a response to conversations.members
+type ConversationsMembersResponse struct {
+	slackResponse
+	Members          []string         `json:"members"`
+	ResponseMetadata responseMetadata `json:"response_metadata,omitempty"`
+}
+
+// ConversationsList returns conversations in the workspace, optionally
+// restricted to the given types (e.g. "public_channel", "private_channel",
+// "mpim", "im"). Pass the cursor from a previous response's
+// ResponseMetadata.NextCursor to fetch the next page.
+func (s *Slack) ConversationsList(types []string, cursor string, limit int) (*ConversationListResponse, error) {
+	params := url.Values{}
+	appendNotEmpty("types", strings.Join(types, ","), params)
+	appendNotEmpty("cursor", cursor, params)
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	r := &ConversationListResponse{}
+	if err := s.do("conversations.list", params, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ConversationsInfo returns info about a single conversation
+func (s *Slack) ConversationsInfo(channel string) (*ConversationResponse, error) {
+	params := url.Values{"channel": {channel}}
+	r := &ConversationResponse{}
+	if err := s.do("conversations.info", params, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ConversationsHistory returns a page of messages from a conversation. Pass
+// the cursor from a previous response's ResponseMetadata.NextCursor to fetch
+// the next page.
+func (s *Slack) ConversationsHistory(channel, cursor string, limit int) (*ConversationsHistoryResponse, error) {
+	params := url.Values{"channel": {channel}}
+	appendNotEmpty("cursor", cursor, params)
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	r := &ConversationsHistoryResponse{}
+	if err := s.do("conversations.history", params, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ConversationsMembers returns the member IDs of a conversation. Pass the
+// cursor from a previous response's ResponseMetadata.NextCursor to fetch the
+// next page.
+func (s *Slack) ConversationsMembers(channel, cursor string, limit int) (*ConversationsMembersResponse, error) {
+	params := url.Values{"channel": {channel}}
+	appendNotEmpty("cursor", cursor, params)
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	r := &ConversationsMembersResponse{}
+	if err := s.do("conversations.members", params, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ConversationsJoin joins the calling user to a conversation
+func (s *Slack) ConversationsJoin(channel string) (*ConversationResponse, error) {
+	params := url.Values{"channel": {channel}}
+	r := &ConversationResponse{}
+	if err := s.do("conversations.join", params, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ConversationsLeave removes the calling user from a conversation
+func (s *Slack) ConversationsLeave(channel string) (Response, error) {
+	params := url.Values{"channel": {channel}}
+	r := &slackResponse{}
+	if err := s.do("conversations.leave", params, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ConversationsArchive archives a conversation
+func (s *Slack) ConversationsArchive(channel string) (Response, error) {
+	params := url.Values{"channel": {channel}}
+	r := &slackResponse{}
+	if err := s.do("conversations.archive", params, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ConversationsCreate creates a new conversation, optionally as a private
+// channel
+func (s *Slack) ConversationsCreate(name string, isPrivate bool) (*ConversationResponse, error) {
+	params := url.Values{"name": {name}}
+	if isPrivate {
+		params.Set("is_private", "true")
+	}
+	r := &ConversationResponse{}
+	if err := s.do("conversations.create", params, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ConversationsInvite invites one or more users to a conversation
+func (s *Slack) ConversationsInvite(channel string, users []string) (*ConversationResponse, error) {
+	params := url.Values{"channel": {channel}, "users": {strings.Join(users, ",")}}
+	r := &ConversationResponse{}
+	if err := s.do("conversations.invite", params, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ConversationsKick removes a user from a conversation
+func (s *Slack) ConversationsKick(channel, user string) (Response, error) {
+	params := url.Values{"channel": {channel}, "user": {user}}
+	r := &slackResponse{}
+	if err := s.do("conversations.kick", params, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ConversationsMark marks a conversation as read up to ts. It replaces the
+// channel/group/im string-prefix dispatch Mark used to do (Mark now just
+// delegates here), since conversations.mark works uniformly across every
+// conversation type.
+func (s *Slack) ConversationsMark(channel, ts string) error {
+	params := url.Values{"channel": {channel}, "ts": {ts}}
+	r := &slackResponse{}
+	return s.do("conversations.mark", params, r)
+}
+
+// conversationToChannel adapts a Conversation to the deprecated Channel
+// shape for the channels.* wrapper methods. IsGeneral and IsMember have no
+// Conversation equivalent and are left false.
+func conversationToChannel(c Conversation) Channel {
+	return Channel{BaseChannel: c.BaseChannel, IsChannel: c.IsChannel}
+}
+
+// conversationToGroup adapts a Conversation to the deprecated Group shape
+// for the groups.* wrapper methods.
+func conversationToGroup(c Conversation) Group {
+	return Group{BaseChannel: c.BaseChannel, IsGroup: c.IsGroup || c.IsPrivate}
+}
@@ -0,0 +1,439 @@
+package slack
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ProgressFunc is called periodically during Export/Import so callers can
+// report progress. total is 0 when it isn't known up front.
+type ProgressFunc func(stage string, current, total int)
+
+// exportUser is the users.json entry in the Slack export layout
+type exportUser struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Deleted bool   `json:"deleted,omitempty"`
+}
+
+// exportChannel is the channels.json entry in the Slack export layout
+type exportChannel struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Creator string   `json:"creator"`
+	Created int64    `json:"created"`
+	Members []string `json:"members"`
+	// IsPrivate records whether this entry came from GroupList rather than
+	// ChannelList, so Importer.importChannel can recreate it as a private
+	// group instead of a public channel.
+	IsPrivate bool `json:"is_private,omitempty"`
+}
+
+// usersListResponse is the response to users.list
+type usersListResponse struct {
+	slackResponse
+	Members []exportUser `json:"members"`
+}
+
+// historyResponse is the response to channels.history / groups.history
+type historyResponse struct {
+	slackResponse
+	Messages []Message `json:"messages"`
+	HasMore  bool      `json:"has_more"`
+}
+
+// ExportOptions configures an Exporter
+type ExportOptions struct {
+	// DryRun, when true, walks the workspace and reports what would be
+	// exported without downloading files or writing zip entries.
+	DryRun bool
+	// Progress, if set, is called as the export proceeds.
+	Progress ProgressFunc
+}
+
+// Exporter writes a workspace out in the Slack export zip layout
+// (channels.json, users.json, one <channel>/YYYY-MM-DD.json per day, and
+// __uploads/<file_id>/<name> for referenced files) understood by tools such
+// as Mattermost's slackimport.
+type Exporter struct {
+	s    *Slack
+	opts ExportOptions
+}
+
+// NewExporter returns an Exporter that reads from s
+func NewExporter(s *Slack, opts ExportOptions) *Exporter {
+	return &Exporter{s: s, opts: opts}
+}
+
+func (e *Exporter) progress(stage string, current, total int) {
+	if e.opts.Progress != nil {
+		e.opts.Progress(stage, current, total)
+	}
+}
+
+// Export walks the workspace's channels, groups and message history and
+// writes a Slack-export-formatted zip to w. Files referenced by messages are
+// downloaded into __uploads/<file_id>/<name> using the bot token.
+func (e *Exporter) Export(w io.Writer) error {
+	channels, err := e.s.ChannelList(false)
+	if err != nil {
+		return err
+	}
+	groups, err := e.s.GroupList(false)
+	if err != nil {
+		return err
+	}
+
+	var zw *zip.Writer
+	if !e.opts.DryRun {
+		zw = zip.NewWriter(w)
+		defer zw.Close()
+	}
+
+	all := make([]exportChannel, 0, len(channels.Channels)+len(groups.Groups))
+	histPaths := map[string]string{}
+	for _, c := range channels.Channels {
+		all = append(all, exportChannel{ID: c.ID, Name: c.Name, Creator: c.Creator, Created: c.Created, Members: c.Members})
+		histPaths[c.ID] = "channels.history"
+	}
+	for _, g := range groups.Groups {
+		all = append(all, exportChannel{ID: g.ID, Name: g.Name, Creator: g.Creator, Created: g.Created, Members: g.Members, IsPrivate: true})
+		histPaths[g.ID] = "groups.history"
+	}
+
+	if err := e.writeJSON(zw, "channels.json", all); err != nil {
+		return err
+	}
+
+	users, err := e.exportUsers()
+	if err != nil {
+		return err
+	}
+	if err := e.writeJSON(zw, "users.json", users); err != nil {
+		return err
+	}
+
+	for i, c := range all {
+		e.progress("export", i, len(all))
+		if err := e.exportChannelHistory(zw, c, histPaths[c.ID]); err != nil {
+			return fmt.Errorf("export %s: %w", c.Name, err)
+		}
+	}
+	e.progress("export", len(all), len(all))
+	return nil
+}
+
+func (e *Exporter) exportUsers() ([]exportUser, error) {
+	r := &usersListResponse{}
+	if err := e.s.do("users.list", url.Values{}, r); err != nil {
+		return nil, err
+	}
+	return r.Members, nil
+}
+
+// exportChannelHistory fetches the full history of a channel/group, groups
+// it by day, writes one JSON file per day, and downloads any referenced
+// files into __uploads/<file_id>/<name>.
+func (e *Exporter) exportChannelHistory(zw *zip.Writer, c exportChannel, historyPath string) error {
+	byDay := map[string][]Message{}
+	latest := ""
+	for {
+		params := url.Values{"channel": {c.ID}, "count": {"1000"}, "inclusive": {"0"}}
+		appendNotEmpty("latest", latest, params)
+		r := &historyResponse{}
+		if err := e.s.do(historyPath, params, r); err != nil {
+			return err
+		}
+		for _, m := range r.Messages {
+			day := tsToDay(m.Timestamp)
+			byDay[day] = append(byDay[day], m)
+			for _, f := range m.Files {
+				if err := e.exportFile(zw, f); err != nil {
+					return err
+				}
+			}
+		}
+		if !r.HasMore || len(r.Messages) == 0 {
+			break
+		}
+		// *.history returns newest-first; the oldest message of this batch
+		// becomes the next page's upper bound. inclusive=0 above keeps that
+		// boundary message from being re-fetched (and duplicated) on the
+		// next page.
+		latest = r.Messages[len(r.Messages)-1].Timestamp
+	}
+
+	days := make([]string, 0, len(byDay))
+	for d := range byDay {
+		days = append(days, d)
+	}
+	sort.Strings(days)
+	for _, d := range days {
+		// *.history returns newest-first; replay order (and thread parent
+		// resolution in importMessage) both need oldest-first.
+		msgs := byDay[d]
+		sort.Slice(msgs, func(i, j int) bool { return msgs[i].Timestamp < msgs[j].Timestamp })
+		if err := e.writeJSON(zw, path.Join(c.Name, d+".json"), msgs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportFile downloads a file referenced by a message into
+// __uploads/<file_id>/<name>, unless running in dry-run mode.
+func (e *Exporter) exportFile(zw *zip.Writer, f File) error {
+	if e.opts.DryRun || f.URLPrivateDownload == "" {
+		return nil
+	}
+	req, err := http.NewRequest("GET", f.URLPrivateDownload, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+e.s.token)
+	resp, err := e.s.c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("download %s: status %s", f.URLPrivateDownload, resp.Status)
+	}
+	fw, err := zw.Create(path.Join("__uploads", f.ID, f.Name))
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(fw, resp.Body)
+	return err
+}
+
+func (e *Exporter) writeJSON(zw *zip.Writer, name string, v interface{}) error {
+	if e.opts.DryRun {
+		return nil
+	}
+	fw, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(fw).Encode(v)
+}
+
+// tsToDay converts a Slack message timestamp ("1234567890.123456") to the
+// YYYY-MM-DD day it falls on, as used for export file names.
+func tsToDay(ts string) string {
+	secs := ts
+	if i := strings.Index(ts, "."); i >= 0 {
+		secs = ts[:i]
+	}
+	sec, err := strconv.ParseInt(secs, 10, 64)
+	if err != nil {
+		return "unknown"
+	}
+	return time.Unix(sec, 0).UTC().Format("2006-01-02")
+}
+
+// ImportOptions configures an Importer
+type ImportOptions struct {
+	// DryRun, when true, parses the archive and reports what would be
+	// imported without creating channels or posting messages.
+	DryRun bool
+	// Progress, if set, is called as the import proceeds.
+	Progress ProgressFunc
+}
+
+// Importer reads a Slack export zip (as produced by Exporter, or by
+// slack-advanced-exporter / the native Slack workspace export) and replays
+// its channels and messages into the workspace behind s.
+type Importer struct {
+	s    *Slack
+	opts ImportOptions
+
+	// tsMap maps an original message ts to the ts it was re-posted under,
+	// so thread parent/child relationships survive re-posting.
+	tsMap map[string]string
+}
+
+// NewImporter returns an Importer that posts into the workspace behind s
+func NewImporter(s *Slack, opts ImportOptions) *Importer {
+	return &Importer{s: s, opts: opts, tsMap: map[string]string{}}
+}
+
+func (im *Importer) progress(stage string, current, total int) {
+	if im.opts.Progress != nil {
+		im.opts.Progress(stage, current, total)
+	}
+}
+
+// Import reads a Slack export zip and re-creates its channels and message
+// history in the workspace behind im.s.
+func (im *Importer) Import(zr *zip.Reader) error {
+	users, err := im.readUsers(zr)
+	if err != nil {
+		return err
+	}
+	channels, err := im.readChannels(zr)
+	if err != nil {
+		return err
+	}
+
+	for i, c := range channels {
+		im.progress("import", i, len(channels))
+		if err := im.importChannel(zr, c, users); err != nil {
+			return fmt.Errorf("import %s: %w", c.Name, err)
+		}
+	}
+	im.progress("import", len(channels), len(channels))
+	return nil
+}
+
+func (im *Importer) readUsers(zr *zip.Reader) (map[string]exportUser, error) {
+	f, err := zr.Open("users.json")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var list []exportUser
+	if err := json.NewDecoder(f).Decode(&list); err != nil {
+		return nil, err
+	}
+	byID := make(map[string]exportUser, len(list))
+	for _, u := range list {
+		byID[u.ID] = u
+	}
+	return byID, nil
+}
+
+func (im *Importer) readChannels(zr *zip.Reader) ([]exportChannel, error) {
+	f, err := zr.Open("channels.json")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var list []exportChannel
+	if err := json.NewDecoder(f).Decode(&list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// importChannel creates the channel (unless DryRun) and replays each of its
+// daily history files in order.
+func (im *Importer) importChannel(zr *zip.Reader, c exportChannel, users map[string]exportUser) error {
+	channelID := c.ID
+	if !im.opts.DryRun {
+		if c.IsPrivate {
+			resp, err := im.s.GroupCreate(c.Name)
+			if err != nil {
+				return err
+			}
+			channelID = resp.Group.ID
+		} else {
+			resp, err := im.s.ChannelCreate(c.Name)
+			if err != nil {
+				return err
+			}
+			channelID = resp.Channel.ID
+		}
+	}
+
+	var days []string
+	prefix := c.Name + "/"
+	for _, f := range zr.File {
+		if strings.HasPrefix(f.Name, prefix) && strings.HasSuffix(f.Name, ".json") {
+			days = append(days, f.Name)
+		}
+	}
+	sort.Strings(days)
+
+	for _, day := range days {
+		msgs, err := im.readDay(zr, day)
+		if err != nil {
+			return err
+		}
+		for _, m := range msgs {
+			if err := im.importMessage(zr, channelID, m, users); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (im *Importer) readDay(zr *zip.Reader, name string) ([]Message, error) {
+	f, err := zr.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var msgs []Message
+	if err := json.NewDecoder(f).Decode(&msgs); err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
+// importMessage re-posts a single exported message, re-uploading any
+// attached files and preserving thread parent/child relationships by
+// mapping the original ts to the newly-issued one.
+func (im *Importer) importMessage(zr *zip.Reader, channel string, m Message, users map[string]exportUser) error {
+	if im.opts.DryRun {
+		return nil
+	}
+	text := m.Text
+	if u, ok := users[m.User]; ok {
+		text = fmt.Sprintf("*%s*: %s", u.Name, text)
+	}
+
+	params := url.Values{"channel": {channel}, "text": {text}, "as_user": {"true"}}
+	if m.ThreadTimestamp != "" {
+		if newParent, ok := im.tsMap[m.ThreadTimestamp]; ok {
+			params.Set("thread_ts", newParent)
+		}
+	}
+
+	r := &struct {
+		slackResponse
+		Timestamp string `json:"ts"`
+	}{}
+	if err := im.s.do("chat.postMessage", params, r); err != nil {
+		return err
+	}
+	im.tsMap[m.Timestamp] = r.Timestamp
+
+	for _, f := range m.Files {
+		rc, err := im.openUpload(zr, f)
+		if err != nil {
+			return err
+		}
+		if rc == nil {
+			continue
+		}
+		_, err = im.s.Upload(f.Title, f.Filetype, f.Name, "", []string{channel}, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// openUpload locates the __uploads/<file_id>/<name> entry for f within the
+// archive currently being imported, if any.
+func (im *Importer) openUpload(zr *zip.Reader, f File) (io.ReadCloser, error) {
+	prefix := path.Join("__uploads", f.ID) + "/"
+	for _, zf := range zr.File {
+		if strings.HasPrefix(zf.Name, prefix) {
+			return zf.Open()
+		}
+	}
+	return nil, nil
+}
@@ -77,6 +77,24 @@ type FileUploadResponse struct {
 	File File `json:"file"`
 }
 
+// getUploadURLExternalResponse is the response to files.getUploadURLExternal
+type getUploadURLExternalResponse struct {
+	slackResponse
+	UploadURL string `json:"upload_url"`
+	FileID    string `json:"file_id"`
+}
+
+// completeUploadExternalFile describes a single uploaded file in the
+// files.completeUploadExternal request body
+type completeUploadExternalFile struct {
+	ID    string `json:"id"`
+	Title string `json:"title,omitempty"`
+}
+
+// maxCompleteUploadAttempts bounds how long UploadExternal waits for the
+// file to show up in the target channel after completion
+const maxCompleteUploadAttempts = 5
+
 type paging struct {
 	Count int `json:"count"`
 	Total int `json:"total"`
@@ -105,9 +123,17 @@ type CommentResponse struct {
 	Comment Comment `json:"comment"`
 }
 
+// uploadPart is an additional named file part attached to a doUpload
+// request, e.g. a generated thumbnail alongside the main file.
+type uploadPart struct {
+	field    string
+	filename string
+	data     io.Reader
+}
+
 // doUpload executes the API request for file upload
 // Returns the response if the status code is between 200 and 299
-func (s *Slack) doUpload(path, filename string, params url.Values, data io.Reader, result interface{}) error {
+func (s *Slack) doUpload(path, filename string, params url.Values, data io.Reader, result interface{}, extra ...uploadPart) error {
 	appendNotEmpty("token", s.token, params)
 	var t time.Time
 	if s.tracelog != nil {
@@ -133,6 +159,17 @@ func (s *Slack) doUpload(path, filename string, params url.Values, data io.Reade
 			errChan <- err
 			return
 		}
+		for _, ep := range extra {
+			epPart, err := writer.CreateFormFile(ep.field, ep.filename)
+			if err != nil {
+				errChan <- err
+				return
+			}
+			if _, err := io.Copy(epPart, ep.data); err != nil {
+				errChan <- err
+				return
+			}
+		}
 		for k, v := range params {
 			if err := writer.WriteField(k, v[0]); err != nil {
 				errChan <- err
@@ -189,6 +226,19 @@ func (s *Slack) Upload(title, filetype, filename, initialComment string, channel
 	if filename == "" {
 		return nil, fmt.Errorf("You must specify the filename for the upload")
 	}
+	// UploadExternal has no filetype param and, unlike files.upload, can
+	// only share the new file on a single channel at completion time, so
+	// only dispatch to it when neither is needed; otherwise fall through to
+	// the legacy multipart path below.
+	if strings.HasPrefix(s.token, "xoxb-") && filetype == "" && len(channels) <= 1 {
+		if rs, ok := data.(io.ReadSeeker); ok {
+			var channel, threadTs string
+			if len(channels) > 0 {
+				channel = channels[0]
+			}
+			return s.UploadExternal(title, filename, initialComment, channel, threadTs, rs)
+		}
+	}
 	params := url.Values{}
 	appendNotEmpty("title", title, params)
 	appendNotEmpty("filetype", filetype, params)
@@ -205,6 +255,101 @@ func (s *Slack) Upload(title, filetype, filename, initialComment string, channel
 	return r, nil
 }
 
+// getUploadURLExternal requests a pre-signed upload URL and file ID for the
+// files.getUploadURLExternal / files.completeUploadExternal handshake
+func (s *Slack) getUploadURLExternal(filename string, length int64) (*getUploadURLExternalResponse, error) {
+	params := url.Values{"filename": {filename}, "length": {strconv.FormatInt(length, 10)}}
+	r := &getUploadURLExternalResponse{}
+	if err := s.do("files.getUploadURLExternal", params, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// putUploadExternal streams data as the PUT body of a pre-signed upload URL
+func (s *Slack) putUploadExternal(uploadURL string, length int64, data io.Reader) error {
+	req, err := http.NewRequest("PUT", uploadURL, data)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = length
+	s.dumpRequest(req)
+	resp, err := s.c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	s.dumpResponse(resp)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("upload to %s failed with status %s", uploadURL, resp.Status)
+	}
+	return nil
+}
+
+// completeUploadExternal finalizes one or more externally-uploaded files and
+// optionally shares them on a channel
+func (s *Slack) completeUploadExternal(files []completeUploadExternalFile, channel, initialComment, threadTs string) (*FileUploadResponse, error) {
+	encoded, err := json.Marshal(files)
+	if err != nil {
+		return nil, err
+	}
+	params := url.Values{"files": {string(encoded)}}
+	appendNotEmpty("channel_id", channel, params)
+	appendNotEmpty("initial_comment", initialComment, params)
+	appendNotEmpty("thread_ts", threadTs, params)
+	r := &FileUploadResponse{}
+	if err := s.do("files.completeUploadExternal", params, r); err != nil {
+		return nil, err
+	}
+	// files.completeUploadExternal does not always return the full File
+	// object straight away, so poll files.info until the file is complete
+	// and, when a channel was given, listed on that channel, or we give up
+	// after maxCompleteUploadAttempts
+	if r.File.ID == "" && len(files) > 0 {
+		r.File.ID = files[0].ID
+	}
+	for attempt := 0; attempt < maxCompleteUploadAttempts; attempt++ {
+		info, err := s.FileInfo(r.File.ID, 0, 0)
+		if err == nil && info.File.Name != "" {
+			r.File = info.File
+			shared := channel == ""
+			for _, c := range append(append([]string{}, info.File.Channels...), info.File.Groups...) {
+				shared = shared || c == channel
+			}
+			if shared {
+				break
+			}
+		}
+		time.Sleep(time.Duration(attempt+1) * 200 * time.Millisecond)
+	}
+	return r, nil
+}
+
+// UploadExternal uploads a file via the files.getUploadURLExternal /
+// files.completeUploadExternal handshake that replaces files.upload for bot
+// tokens. data must support seeking so its length can be determined without
+// buffering the whole file in memory.
+func (s *Slack) UploadExternal(title, filename, initialComment, channel, threadTs string, data io.ReadSeeker) (*FileUploadResponse, error) {
+	if filename == "" {
+		return nil, fmt.Errorf("You must specify the filename for the upload")
+	}
+	length, err := data.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := data.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	urlResp, err := s.getUploadURLExternal(filename, length)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.putUploadExternal(urlResp.UploadURL, length, data); err != nil {
+		return nil, err
+	}
+	return s.completeUploadExternal([]completeUploadExternalFile{{ID: urlResp.FileID, Title: title}}, channel, initialComment, threadTs)
+}
+
 // FileList the files for the team
 func (s *Slack) FileList(user, tsFrom, tsTo string, types []string, count, page int) (*FileListResponse, error) {
 	params := url.Values{}
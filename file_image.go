@@ -0,0 +1,196 @@
+package slack
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	"github.com/rwcarlsen/goexif/exif"
+)
+
+// defaultJPEGQuality is used when UploadOptions.JPEGQuality is unset
+const defaultJPEGQuality = 90
+
+// UploadOptions configures the optional image processing Slack.Upload can
+// apply before sending a file.
+type UploadOptions struct {
+	// AutoOrient parses the EXIF orientation tag of a JPEG upload and
+	// rotates/flips the image so it displays upright, then strips the tag.
+	AutoOrient bool
+	// GenerateThumbnails produces one additional fixed-width JPEG thumbnail
+	// per entry, attached as extra multipart parts named thumb_<width>.
+	GenerateThumbnails []int
+	// JPEGQuality controls the re-encode quality used by AutoOrient and
+	// thumbnail generation. Defaults to defaultJPEGQuality.
+	JPEGQuality int
+}
+
+// UploadWithOptions uploads a file like Upload, additionally applying
+// EXIF-orientation correction and/or thumbnail generation as requested by
+// opts. Only JPEG input is processed; other filetypes are uploaded as-is.
+// The primary upload body is only modified when opts.AutoOrient is set;
+// requesting thumbnails alone leaves the original image untouched. Unlike
+// Upload, this always goes through the legacy files.upload endpoint rather
+// than dispatching bot tokens to UploadExternal, since attaching generated
+// thumbnails as extra multipart parts isn't supported by the
+// getUploadURLExternal/completeUploadExternal handshake.
+func (s *Slack) UploadWithOptions(title, filetype, filename, initialComment string, channels []string, data io.Reader, opts UploadOptions) (*FileUploadResponse, error) {
+	if filename == "" {
+		return nil, fmt.Errorf("You must specify the filename for the upload")
+	}
+	if !opts.AutoOrient && len(opts.GenerateThumbnails) == 0 {
+		return s.Upload(title, filetype, filename, initialComment, channels, data)
+	}
+	if !strings.HasSuffix(strings.ToLower(filename), ".jpg") && !strings.HasSuffix(strings.ToLower(filename), ".jpeg") {
+		return s.Upload(title, filetype, filename, initialComment, channels, data)
+	}
+
+	quality := opts.JPEGQuality
+	if quality <= 0 {
+		quality = defaultJPEGQuality
+	}
+
+	buf, err := io.ReadAll(data)
+	if err != nil {
+		return nil, err
+	}
+	img, orientation, err := decodeJPEGAndOrientation(bytes.NewReader(buf))
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the decoded image needs correcting for thumbnail generation;
+	// the primary upload body is only rotated/flipped and re-encoded when
+	// AutoOrient was actually requested.
+	img = applyOrientation(img, orientation)
+	var body io.Reader = bytes.NewReader(buf)
+	if opts.AutoOrient {
+		var out bytes.Buffer
+		if err := jpeg.Encode(&out, img, &jpeg.Options{Quality: quality}); err != nil {
+			return nil, err
+		}
+		body = &out
+	}
+
+	var thumbs []uploadPart
+	for _, width := range opts.GenerateThumbnails {
+		thumb, err := encodeThumbnail(img, width, quality)
+		if err != nil {
+			return nil, err
+		}
+		thumbs = append(thumbs, uploadPart{
+			field:    "thumb_" + strconv.Itoa(width),
+			filename: "thumb_" + strconv.Itoa(width) + "_" + filename,
+			data:     thumb,
+		})
+	}
+
+	params := url.Values{}
+	appendNotEmpty("title", title, params)
+	appendNotEmpty("filetype", filetype, params)
+	appendNotEmpty("filename", filename, params)
+	appendNotEmpty("initial_comment", initialComment, params)
+	if len(channels) > 0 {
+		params.Set("channels", strings.Join(channels, ","))
+	}
+	r := &FileUploadResponse{}
+	// Always goes through the legacy files.upload multipart endpoint rather
+	// than Upload's bot-token dispatch to UploadExternal: attaching the
+	// generated thumbnails as extra multipart parts has no equivalent in the
+	// getUploadURLExternal/completeUploadExternal handshake, which only
+	// accepts a single file per upload URL.
+	if err := s.doUpload("files.upload", filename, params, body, r, thumbs...); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// CorrectOrientation reads a JPEG from r, rotates/flips it according to its
+// EXIF orientation tag (0x0112, values 1-8), strips the tag, and re-encodes
+// it at the given JPEG quality. It returns a reader positioned at the start
+// of the corrected image along with the decoded, already-corrected image so
+// callers who also need thumbnails don't have to decode twice. If r has no
+// EXIF orientation tag, or the tag is already 1 (identity), the image is
+// returned unmodified (but still decoded/re-encoded).
+func CorrectOrientation(r io.Reader, quality int) (io.Reader, image.Image, error) {
+	img, orientation, err := decodeJPEGAndOrientation(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	img = applyOrientation(img, orientation)
+
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, img, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, nil, err
+	}
+	return &out, img, nil
+}
+
+// decodeJPEGAndOrientation reads a JPEG from r and returns the decoded,
+// *uncorrected* image along with its EXIF orientation tag (1 if absent or
+// unreadable). Callers that don't need orientation applied to the image
+// itself (e.g. because they only need it to generate thumbnails) can use the
+// orientation value on its own.
+func decodeJPEGAndOrientation(r io.Reader) (image.Image, int, error) {
+	// exif and jpeg decoding both need random access, so buffer the whole
+	// image rather than trying to tee the single-pass reader doUpload uses.
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	orientation := 1
+	if x, err := exif.Decode(bytes.NewReader(buf)); err == nil {
+		if tag, err := x.Get(exif.Orientation); err == nil {
+			if v, err := tag.Int(0); err == nil {
+				orientation = v
+			}
+		}
+	}
+
+	img, err := jpeg.Decode(bytes.NewReader(buf))
+	if err != nil {
+		return nil, 0, err
+	}
+	return img, orientation, nil
+}
+
+// applyOrientation maps an EXIF orientation tag value (1-8) to the
+// corresponding rotate/flip transform.
+func applyOrientation(img image.Image, orientation int) image.Image {
+	switch orientation {
+	case 2:
+		return imaging.FlipH(img)
+	case 3:
+		return imaging.Rotate180(img)
+	case 4:
+		return imaging.FlipV(img)
+	case 5:
+		return imaging.Transpose(img)
+	case 6:
+		return imaging.Rotate270(img)
+	case 7:
+		return imaging.Transverse(img)
+	case 8:
+		return imaging.Rotate90(img)
+	default:
+		return img
+	}
+}
+
+// encodeThumbnail resizes img to the given width (preserving aspect ratio)
+// and returns it as a JPEG-encoded reader.
+func encodeThumbnail(img image.Image, width, quality int) (io.Reader, error) {
+	thumb := imaging.Resize(img, width, 0, imaging.Lanczos)
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, thumb, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
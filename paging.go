@@ -0,0 +1,246 @@
+package slack
+
+// FileListIterator walks the pages of a files.list call, issuing successive
+// requests as needed. A zero-value FileListIterator is not usable; get one
+// from Slack.FileListIterator.
+type FileListIterator struct {
+	s *Slack
+
+	user, tsFrom, tsTo string
+	types              []string
+	count              int
+
+	page, pages int
+	files       []File
+	idx         int
+	cur         File
+	err         error
+	started     bool
+}
+
+// FileListIterator returns an iterator over every file matching the given
+// filters, transparently issuing successive files.list calls as each page
+// is exhausted.
+func (s *Slack) FileListIterator(user, tsFrom, tsTo string, types []string, count int) *FileListIterator {
+	return &FileListIterator{s: s, user: user, tsFrom: tsFrom, tsTo: tsTo, types: types, count: count}
+}
+
+// Next advances the iterator, fetching the next page from files.list if the
+// current one is exhausted. It returns false when there are no more files
+// or an error occurred; check Err to distinguish the two.
+func (it *FileListIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	for it.idx >= len(it.files) {
+		if it.started && it.page >= it.pages {
+			return false
+		}
+		it.page++
+		r, err := it.s.FileList(it.user, it.tsFrom, it.tsTo, it.types, it.count, it.page)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.started = true
+		it.pages = r.Paging.Pages
+		it.files = r.Files
+		it.idx = 0
+		if len(it.files) == 0 {
+			return false
+		}
+	}
+	it.cur = it.files[it.idx]
+	it.idx++
+	return true
+}
+
+// File returns the file at the iterator's current position
+func (it *FileListIterator) File() File {
+	return it.cur
+}
+
+// Err returns the first error encountered while paging, if any
+func (it *FileListIterator) Err() error {
+	return it.err
+}
+
+// FileListAll returns a channel streaming every file matching the given
+// filters, paging through files.list internally. The channel is closed when
+// iteration completes or fails; errors are discarded, so callers that need
+// to observe them should use FileListIterator directly instead.
+//
+// Close done (or pass a done channel you close on early exit) if the
+// consumer may stop ranging over the result before it's drained; otherwise
+// the internal goroutine blocks forever trying to send. Pass nil if the
+// caller always drains the channel fully.
+func (s *Slack) FileListAll(done <-chan struct{}, user, tsFrom, tsTo string, types []string, count int) <-chan File {
+	out := make(chan File)
+	go func() {
+		defer close(out)
+		it := s.FileListIterator(user, tsFrom, tsTo, types, count)
+		for it.Next() {
+			select {
+			case out <- it.File():
+			case <-done:
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// ChannelListIterator walks a ChannelList result one channel at a time.
+// ChannelList already follows every conversations.list cursor before it
+// returns, so this makes a single ChannelList call on the first Next and
+// then walks the collected channels.
+type ChannelListIterator struct {
+	s               *Slack
+	excludeArchived bool
+
+	fetched  bool
+	channels []Channel
+	idx      int
+	cur      Channel
+	err      error
+}
+
+// ChannelListIterator returns an iterator over every channel
+func (s *Slack) ChannelListIterator(excludeArchived bool) *ChannelListIterator {
+	return &ChannelListIterator{s: s, excludeArchived: excludeArchived}
+}
+
+// Next advances the iterator. It returns false once every channel has been
+// visited or an error occurred; check Err to distinguish the two.
+func (it *ChannelListIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if !it.fetched {
+		r, err := it.s.ChannelList(it.excludeArchived)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.fetched = true
+		it.channels = r.Channels
+	}
+	if it.idx >= len(it.channels) {
+		return false
+	}
+	it.cur = it.channels[it.idx]
+	it.idx++
+	return true
+}
+
+// Channel returns the channel at the iterator's current position
+func (it *ChannelListIterator) Channel() Channel {
+	return it.cur
+}
+
+// Err returns the first error encountered while paging, if any
+func (it *ChannelListIterator) Err() error {
+	return it.err
+}
+
+// ChannelListAll returns a channel streaming every channel in the
+// workspace. The channel is closed when iteration completes or fails;
+// callers that need to observe errors should use ChannelListIterator
+// directly instead.
+//
+// Close done (or pass a done channel you close on early exit) if the
+// consumer may stop ranging over the result before it's drained; otherwise
+// the internal goroutine blocks forever trying to send. Pass nil if the
+// caller always drains the channel fully.
+func (s *Slack) ChannelListAll(done <-chan struct{}, excludeArchived bool) <-chan Channel {
+	out := make(chan Channel)
+	go func() {
+		defer close(out)
+		it := s.ChannelListIterator(excludeArchived)
+		for it.Next() {
+			select {
+			case out <- it.Channel():
+			case <-done:
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// GroupListIterator walks a GroupList result one group at a time. Like
+// ChannelListIterator, it makes a single GroupList call, which itself
+// follows every conversations.list cursor, and then walks the collected
+// groups.
+type GroupListIterator struct {
+	s               *Slack
+	excludeArchived bool
+
+	fetched bool
+	groups  []Group
+	idx     int
+	cur     Group
+	err     error
+}
+
+// GroupListIterator returns an iterator over every group
+func (s *Slack) GroupListIterator(excludeArchived bool) *GroupListIterator {
+	return &GroupListIterator{s: s, excludeArchived: excludeArchived}
+}
+
+// Next advances the iterator. It returns false once every group has been
+// visited or an error occurred; check Err to distinguish the two.
+func (it *GroupListIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if !it.fetched {
+		r, err := it.s.GroupList(it.excludeArchived)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.fetched = true
+		it.groups = r.Groups
+	}
+	if it.idx >= len(it.groups) {
+		return false
+	}
+	it.cur = it.groups[it.idx]
+	it.idx++
+	return true
+}
+
+// Group returns the group at the iterator's current position
+func (it *GroupListIterator) Group() Group {
+	return it.cur
+}
+
+// Err returns the first error encountered while paging, if any
+func (it *GroupListIterator) Err() error {
+	return it.err
+}
+
+// GroupListAll returns a channel streaming every group in the workspace.
+// The channel is closed when iteration completes or fails; callers that
+// need to observe errors should use GroupListIterator directly instead.
+//
+// Close done (or pass a done channel you close on early exit) if the
+// consumer may stop ranging over the result before it's drained; otherwise
+// the internal goroutine blocks forever trying to send. Pass nil if the
+// caller always drains the channel fully.
+func (s *Slack) GroupListAll(done <-chan struct{}, excludeArchived bool) <-chan Group {
+	out := make(chan Group)
+	go func() {
+		defer close(out)
+		it := s.GroupListIterator(excludeArchived)
+		for it.Next() {
+			select {
+			case out <- it.Group():
+			case <-done:
+				return
+			}
+		}
+	}()
+	return out
+}